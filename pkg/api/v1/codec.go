@@ -0,0 +1,25 @@
+package v1
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// jsonCodec implements google.golang.org/grpc/encoding.Codec over plain
+// JSON. The message types in this package are hand-written structs, not
+// protoc-gen-go output, so they don't implement proto.Message — the
+// codec grpc.NewServer registers under the name "proto" by default can't
+// marshal them and every RPC fails with "message is *v1.ClassifyRequest,
+// want proto.Message". Registering this codec under that same name
+// replaces it process-wide, so both serveGRPC and any in-process client
+// built from this package's stubs use JSON on the wire instead.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+func (jsonCodec) Name() string { return "proto" }
+
+func init() { encoding.RegisterCodec(jsonCodec{}) }
@@ -0,0 +1,117 @@
+// Hand-written message types for api/proto/v1/uvspeed.proto. These are
+// plain structs, not protoc-gen-go output; see codec.go.
+
+package v1
+
+// ClassifyRequest is one full-source classification request.
+type ClassifyRequest struct {
+	Source   string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Language string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *ClassifyRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ClassifyRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+// ClassifyResponse mirrors the JSON shape returned by the HTTP endpoint.
+type ClassifyResponse struct {
+	// No omitempty: these are marshaled with encoding/json (see
+	// codec.go), not protojson, so omitempty would drop the zero-value
+	// "language"/"lines"/"results" keys that /api/classify has always
+	// returned — breaking byte-compatibility for the common case of an
+	// empty-Language request. Fields are declared in alphabetical key
+	// order, the order encoding/json used for the map that endpoint
+	// encoded, so the keys come out in the same order too.
+	Language string            `protobuf:"bytes,2,opt,name=language,proto3" json:"language"`
+	Lines    int32             `protobuf:"varint,3,opt,name=lines,proto3" json:"lines"`
+	Results  []*ClassifyResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results"`
+}
+
+func (x *ClassifyResponse) GetResults() []*ClassifyResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ClassifyResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ClassifyResponse) GetLines() int32 {
+	if x != nil {
+		return x.Lines
+	}
+	return 0
+}
+
+// ClassifyChunk is one line pushed up a StreamClassify call.
+type ClassifyChunk struct {
+	Line     string `protobuf:"bytes,1,opt,name=line,proto3" json:"line,omitempty"`
+	LineNum  int32  `protobuf:"varint,2,opt,name=line_num,json=lineNum,proto3" json:"line_num,omitempty"`
+	Language string `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *ClassifyChunk) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+func (x *ClassifyChunk) GetLineNum() int32 {
+	if x != nil {
+		return x.LineNum
+	}
+	return 0
+}
+
+func (x *ClassifyChunk) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+// ClassifyResult holds the classification of a single line. No
+// omitempty here either, matching the original ClassifyResult struct in
+// main.go, which has never omitted these fields.
+type ClassifyResult struct {
+	Symbol   string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol"`
+	Category string `protobuf:"bytes,2,opt,name=category,proto3" json:"category"`
+	Line     int32  `protobuf:"varint,3,opt,name=line,proto3" json:"line"`
+}
+
+func (x *ClassifyResult) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ClassifyResult) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ClassifyResult) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
@@ -0,0 +1,56 @@
+// Hand-written REST binding for api/proto/v1/uvspeed.proto, shaped like
+// protoc-gen-grpc-gateway output but served from a plain http.ServeMux.
+
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcCodeToHTTPStatus maps the subset of codes this gateway actually
+// produces to HTTP statuses; runtime.HTTPStatusFromCode in the real
+// grpc-gateway covers the rest.
+func grpcCodeToHTTPStatus(c codes.Code) int {
+	switch c {
+	case codes.DeadlineExceeded:
+		return http.StatusServiceUnavailable
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// RegisterQuantumPrefixHandlerServer registers the REST bindings for
+// QuantumPrefix directly against an in-process server implementation,
+// the same pattern protoc-gen-grpc-gateway emits for the
+// "local" (non-dial) registration path.
+func RegisterQuantumPrefixHandlerServer(mux *http.ServeMux, server QuantumPrefixServer) error {
+	mux.HandleFunc("/api/classify", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req ClassifyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			return
+		}
+
+		resp, err := server.Classify(r.Context(), &req)
+		if err != nil {
+			st := status.Convert(err)
+			http.Error(w, st.Message(), grpcCodeToHTTPStatus(st.Code()))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+	return nil
+}
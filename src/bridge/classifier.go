@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Classifier runs classification for a single request and can be given
+// a deadline independent of (and on top of) the caller's ctx. The
+// readDeadline/cancelCh pairing mirrors the netstack deadlineTimer
+// pattern: SetDeadline can be called more than once, and a cancelCh
+// that already fired is swapped for a fresh one rather than reused.
+type Classifier struct {
+	mu           sync.Mutex
+	readDeadline time.Time
+	cancelCh     chan struct{}
+	timer        *time.Timer
+}
+
+// NewClassifier returns a Classifier with no deadline set.
+func NewClassifier() *Classifier {
+	return &Classifier{cancelCh: make(chan struct{})}
+}
+
+// SetDeadline arms (or clears, for a zero t) the classifier's deadline.
+// Calling it again before the previous deadline fires stops the pending
+// timer and reuses the same cancel channel; calling it after the
+// previous deadline already fired allocates a fresh one so callers that
+// raced the old deadline don't see a channel that's permanently closed.
+func (c *Classifier) SetDeadline(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+	// Checked unconditionally, not just when a timer existed: a deadline
+	// already in the past closes cancelCh directly below without ever
+	// arming a timer, so gating this on c.timer != nil would miss that
+	// case and hand the next call a cancelCh that's already closed.
+	select {
+	case <-c.cancelCh:
+		c.cancelCh = make(chan struct{})
+	default:
+	}
+
+	c.readDeadline = t
+	if t.IsZero() {
+		return
+	}
+
+	now := time.Now()
+	if !t.After(now) {
+		close(c.cancelCh)
+		return
+	}
+
+	ch := c.cancelCh
+	c.timer = time.AfterFunc(t.Sub(now), func() { close(ch) })
+}
+
+// done returns the cancel channel current at the time of the call.
+func (c *Classifier) done() <-chan struct{} {
+	c.mu.Lock()
+	ch := c.cancelCh
+	c.mu.Unlock()
+	return ch
+}
+
+// ClassifySource classifies source using the backend registered for
+// language, aborting early if either ctx is canceled or the classifier's
+// own deadline (set via SetDeadline) fires first.
+func (c *Classifier) ClassifySource(ctx context.Context, language, source string) ([]ClassifyResult, error) {
+	deadlineCh := c.done()
+
+	// A deadline that already fired (or was set in the past) must abort
+	// synchronously here: without this check, a source short enough to
+	// classify before the goroutine below gets scheduled would race past
+	// the deadline and succeed anyway.
+	select {
+	case <-deadlineCh:
+		return nil, context.DeadlineExceeded
+	default:
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-deadlineCh:
+			cancel()
+		case <-stop:
+		}
+	}()
+
+	return ClassifySourceWithLanguage(ctx, language, source)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClassifierSetDeadlineInPastClosesImmediately(t *testing.T) {
+	c := NewClassifier()
+	c.SetDeadline(time.Now().Add(-time.Second))
+
+	select {
+	case <-c.done():
+	default:
+		t.Fatal("cancelCh should already be closed for a past deadline")
+	}
+}
+
+func TestClassifierSetDeadlineTwiceBeforeFiringDoesNotFirePremature(t *testing.T) {
+	c := NewClassifier()
+	c.SetDeadline(time.Now().Add(50 * time.Millisecond))
+	c.SetDeadline(time.Now().Add(time.Hour))
+
+	select {
+	case <-c.done():
+		t.Fatal("cancelCh fired even though the first deadline was superseded before it elapsed")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestClassifierSetDeadlineAfterFireAllocatesFreshChannel(t *testing.T) {
+	c := NewClassifier()
+	c.SetDeadline(time.Now().Add(-time.Second))
+
+	select {
+	case <-c.done():
+	default:
+		t.Fatal("expected the first (past) deadline to have fired")
+	}
+
+	c.SetDeadline(time.Now().Add(time.Hour))
+
+	select {
+	case <-c.done():
+		t.Fatal("cancelCh should be a fresh, unfired channel after resetting a deadline that already fired")
+	default:
+	}
+}
+
+func TestClassifierClassifySourceRespectsDeadline(t *testing.T) {
+	c := NewClassifier()
+	c.SetDeadline(time.Now().Add(-time.Second))
+
+	_, err := c.ClassifySource(context.Background(), "", "line one\nline two")
+	if err == nil {
+		t.Fatal("expected an error from a classifier whose deadline already fired")
+	}
+}
+
+func TestClassifierClassifySourceRespectsCallerContext(t *testing.T) {
+	c := NewClassifier()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.ClassifySource(ctx, "", "line one\nline two")
+	if err == nil {
+		t.Fatal("expected an error from an already-canceled caller context")
+	}
+}
+
+func TestClassifierClassifySourceSucceedsWithinDeadline(t *testing.T) {
+	c := NewClassifier()
+	c.SetDeadline(time.Now().Add(time.Hour))
+
+	source := strings.Join([]string{"func main() {}", "return", ""}, "\n")
+	results, err := c.ClassifySource(context.Background(), "", source)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+}
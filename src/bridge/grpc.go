@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	v1 "github.com/fornevercollective/uvspeed/pkg/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcPort is the second port the gRPC server listens on, alongside the
+// HTTP port. Kept separate rather than cmux'd onto the same port so a
+// plain `curl` against grpcPort fails loudly instead of confusingly.
+const grpcPort = "8086"
+
+// quantumPrefixServer implements v1.QuantumPrefixServer on top of the
+// same ClassifySource core the HTTP handlers call, so both transports
+// classify identically.
+type quantumPrefixServer struct {
+	v1.UnimplementedQuantumPrefixServer
+}
+
+// Classify enforces classifyTimeout on top of the caller's ctx via a
+// per-call Classifier, so a slow-loris POST or pathological input can't
+// pin this goroutine forever.
+func (quantumPrefixServer) Classify(ctx context.Context, req *v1.ClassifyRequest) (*v1.ClassifyResponse, error) {
+	c := NewClassifier()
+	c.SetDeadline(time.Now().Add(classifyTimeout))
+
+	results, err := c.ClassifySource(ctx, req.Language, req.Source)
+	if err != nil {
+		return nil, status.Errorf(codes.DeadlineExceeded, "classify: %v", err)
+	}
+
+	return &v1.ClassifyResponse{
+		Results:  toProtoResults(results),
+		Language: req.Language,
+		Lines:    int32(len(results)),
+	}, nil
+}
+
+// StreamClassify classifies each chunk as it arrives and streams back
+// one ClassifyResult per line, so neither side buffers the whole source.
+// It dispatches on each chunk's Language like the unary path, threading
+// the previous result forward as ClassifyState.
+func (quantumPrefixServer) StreamClassify(stream v1.QuantumPrefix_StreamClassifyServer) error {
+	var prev ClassifyResult
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		backend := lookupClassifier(chunk.Language)
+		prev = backend.Classify(chunk.Line, int(chunk.LineNum), ClassifyState{Prev: prev})
+		if err := stream.Send(&v1.ClassifyResult{
+			Symbol:   string(prev.Symbol),
+			Category: prev.Category,
+			Line:     int32(prev.Line),
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+func toProtoResults(results []ClassifyResult) []*v1.ClassifyResult {
+	out := make([]*v1.ClassifyResult, len(results))
+	for i, r := range results {
+		out[i] = &v1.ClassifyResult{
+			Symbol:   string(r.Symbol),
+			Category: r.Category,
+			Line:     int32(r.Line),
+		}
+	}
+	return out
+}
+
+// serveGRPC starts the gRPC server and blocks until it stops serving.
+// Intended to run in its own goroutine from main().
+func serveGRPC() {
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatalf("grpc: listen on :%s: %v", grpcPort, err)
+	}
+
+	s := grpc.NewServer()
+	v1.RegisterQuantumPrefixServer(s, quantumPrefixServer{})
+
+	fmt.Printf("  gRPC listening on :%s\n", grpcPort)
+	if err := s.Serve(lis); err != nil {
+		log.Fatalf("grpc: serve: %v", err)
+	}
+}
+
+// mountGateway wires the grpc-gateway REST bindings for QuantumPrefix
+// onto mux, so /api/classify keeps serving the same JSON it always has,
+// backed by the same Classify implementation the gRPC server uses.
+func mountGateway(mux *http.ServeMux) error {
+	gw := http.NewServeMux()
+	if err := v1.RegisterQuantumPrefixHandlerServer(gw, quantumPrefixServer{}); err != nil {
+		return err
+	}
+	mux.HandleFunc("/api/classify", corsMiddleware(gw.ServeHTTP))
+	return nil
+}
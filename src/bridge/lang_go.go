@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// goClassifier classifies Go source by walking its real AST instead of
+// pattern-matching each line, so indentation, chained calls, and
+// multi-line declarations map to the correct prefix symbol.
+type goClassifier struct{}
+
+func (goClassifier) Capabilities() []string { return []string{"ast", "go/parser"} }
+
+// Classify satisfies LanguageClassifier for callers that only have one
+// line in hand (e.g. StreamClassify); it falls back to the regex
+// classifier since a single line can't be parsed as a Go file on its
+// own. Whole-source requests go through ClassifySource instead, which
+// gets the accurate AST-based result.
+func (g goClassifier) Classify(line string, lineNum int, prev ClassifyState) ClassifyResult {
+	return regexClassifier{}.Classify(line, lineNum, prev)
+}
+
+// ClassifySource walks the parsed AST of source and assigns each line
+// the prefix symbol of the most specific node that starts on it,
+// falling back to the regex classifier for lines no declaration,
+// statement, or comment claims (e.g. closing braces, blank lines).
+func (g goClassifier) ClassifySource(ctx context.Context, source string) ([]ClassifyResult, error) {
+	lines := strings.Split(source, "\n")
+	results := make([]ClassifyResult, len(lines))
+	for i := range results {
+		results[i] = ClassifyResult{Symbol: PrefixZero, Category: "neutral", Line: i + 1}
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", source, parser.ParseComments)
+	if err != nil {
+		// Not valid Go (a fragment, or mid-edit) — regex is still a
+		// reasonable best effort rather than failing the request.
+		for i, line := range lines {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			results[i] = ClassifyLine(ctx, line, i+1)
+		}
+		return results, nil
+	}
+
+	assign := func(pos token.Pos, symbol PrefixSymbol, category string) {
+		if !pos.IsValid() {
+			return
+		}
+		lineNum := fset.Position(pos).Line
+		if lineNum < 1 || lineNum > len(results) {
+			return
+		}
+		results[lineNum-1] = ClassifyResult{Symbol: symbol, Category: category, Line: lineNum}
+	}
+
+	for _, c := range file.Comments {
+		for _, line := range c.List {
+			assign(line.Pos(), PrefixMinusZero, "comment")
+		}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.ImportSpec:
+			assign(node.Pos(), PrefixN, "import")
+		case *ast.FuncDecl:
+			assign(node.Pos(), PrefixPlusOne, "declaration")
+		case *ast.GenDecl:
+			switch node.Tok {
+			case token.TYPE, token.CONST, token.VAR:
+				assign(node.Pos(), PrefixPlusOne, "declaration")
+			}
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt, *ast.CaseClause:
+			assign(n.Pos(), PrefixOne, "logic")
+		case *ast.ReturnStmt:
+			assign(node.Pos(), PrefixPlusN, "modifier")
+		case *ast.BranchStmt:
+			assign(node.Pos(), PrefixPlusN, "modifier")
+		case *ast.AssignStmt:
+			assign(node.Pos(), PrefixPlusZero, "assignment")
+		case *ast.CallExpr:
+			if sel, ok := node.Fun.(*ast.SelectorExpr); ok {
+				if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "fmt" {
+					assign(node.Pos(), PrefixMinusOne, "io")
+				}
+			}
+		}
+		return true
+	})
+
+	for i, line := range lines {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if results[i].Category == "neutral" && strings.TrimSpace(line) == "" {
+			continue
+		}
+		if results[i].Category == "neutral" && strings.TrimSpace(line) != "" {
+			results[i] = ClassifyLine(ctx, line, i+1)
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import "strings"
+
+// jsClassifier covers JavaScript and TypeScript. It's still a
+// HasPrefix/Contains chain like the default backend, but tuned to
+// ES/TS keywords (const/let, arrow functions, TS's type/interface) that
+// the language-agnostic default gets wrong.
+type jsClassifier struct{}
+
+func (jsClassifier) Capabilities() []string { return []string{"line-regex"} }
+
+func (jsClassifier) Classify(line string, lineNum int, _ ClassifyState) ClassifyResult {
+	trimmed := strings.TrimSpace(line)
+
+	if trimmed == "" {
+		return ClassifyResult{PrefixZero, "neutral", lineNum}
+	}
+
+	if strings.HasPrefix(trimmed, "//") || strings.HasPrefix(trimmed, "/*") || strings.HasPrefix(trimmed, "*") {
+		return ClassifyResult{PrefixMinusZero, "comment", lineNum}
+	}
+
+	if strings.HasPrefix(trimmed, "import ") || strings.HasPrefix(trimmed, "export ") && strings.Contains(trimmed, "from ") ||
+		strings.HasPrefix(trimmed, "require(") {
+		return ClassifyResult{PrefixN, "import", lineNum}
+	}
+
+	if strings.HasPrefix(trimmed, "function ") || strings.HasPrefix(trimmed, "async function ") ||
+		strings.HasPrefix(trimmed, "class ") || strings.HasPrefix(trimmed, "const ") ||
+		strings.HasPrefix(trimmed, "let ") || strings.HasPrefix(trimmed, "var ") ||
+		strings.HasPrefix(trimmed, "interface ") || strings.HasPrefix(trimmed, "type ") ||
+		strings.HasPrefix(trimmed, "enum ") || strings.Contains(trimmed, "=>") {
+		return ClassifyResult{PrefixPlusOne, "declaration", lineNum}
+	}
+
+	if strings.HasPrefix(trimmed, "if ") || strings.HasPrefix(trimmed, "if(") ||
+		strings.HasPrefix(trimmed, "else") || strings.HasPrefix(trimmed, "for ") ||
+		strings.HasPrefix(trimmed, "for(") || strings.HasPrefix(trimmed, "while ") ||
+		strings.HasPrefix(trimmed, "switch ") || strings.HasPrefix(trimmed, "case ") {
+		return ClassifyResult{PrefixOne, "logic", lineNum}
+	}
+
+	if strings.HasPrefix(trimmed, "return") || strings.HasPrefix(trimmed, "throw ") ||
+		strings.HasPrefix(trimmed, "break") || strings.HasPrefix(trimmed, "continue") {
+		return ClassifyResult{PrefixPlusN, "modifier", lineNum}
+	}
+
+	if strings.Contains(trimmed, "console.") {
+		return ClassifyResult{PrefixMinusOne, "io", lineNum}
+	}
+
+	if strings.Contains(trimmed, " = ") || strings.Contains(trimmed, " += ") || strings.Contains(trimmed, " -= ") {
+		return ClassifyResult{PrefixPlusZero, "assignment", lineNum}
+	}
+
+	return ClassifyResult{PrefixMinusN, "unknown", lineNum}
+}
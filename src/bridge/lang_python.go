@@ -0,0 +1,80 @@
+package main
+
+import "strings"
+
+// pythonClassifier is a pure-Go tokenizer: it splits a line into its
+// leading keyword (Python has no braces to anchor on, so indentation
+// alone isn't enough) and classifies on that, rather than the generic
+// chain of HasPrefix checks the default backend uses.
+type pythonClassifier struct{}
+
+func (pythonClassifier) Capabilities() []string { return []string{"tokenizer"} }
+
+var pythonKeywords = map[string]struct {
+	symbol   PrefixSymbol
+	category string
+}{
+	"import":   {PrefixN, "import"},
+	"from":     {PrefixN, "import"},
+	"def":      {PrefixPlusOne, "declaration"},
+	"class":    {PrefixPlusOne, "declaration"},
+	"async":    {PrefixPlusOne, "declaration"},
+	"if":       {PrefixOne, "logic"},
+	"elif":     {PrefixOne, "logic"},
+	"else":     {PrefixOne, "logic"},
+	"for":      {PrefixOne, "logic"},
+	"while":    {PrefixOne, "logic"},
+	"with":     {PrefixOne, "logic"},
+	"try":      {PrefixOne, "logic"},
+	"except":   {PrefixOne, "logic"},
+	"finally":  {PrefixOne, "logic"},
+	"match":    {PrefixOne, "logic"},
+	"case":     {PrefixOne, "logic"},
+	"return":   {PrefixPlusN, "modifier"},
+	"yield":    {PrefixPlusN, "modifier"},
+	"raise":    {PrefixPlusN, "modifier"},
+	"break":    {PrefixPlusN, "modifier"},
+	"continue": {PrefixPlusN, "modifier"},
+	"pass":     {PrefixPlusN, "modifier"},
+}
+
+// pythonToken is the first whitespace-delimited word of a line, with any
+// trailing ":", "(" punctuation stripped so "def" and "def(" both match.
+func pythonToken(trimmed string) string {
+	end := len(trimmed)
+	for i, r := range trimmed {
+		if r == ' ' || r == '\t' || r == '(' || r == ':' {
+			end = i
+			break
+		}
+	}
+	return trimmed[:end]
+}
+
+func (pythonClassifier) Classify(line string, lineNum int, _ ClassifyState) ClassifyResult {
+	trimmed := strings.TrimSpace(line)
+
+	if trimmed == "" {
+		return ClassifyResult{PrefixZero, "neutral", lineNum}
+	}
+	if strings.HasPrefix(trimmed, "#") {
+		return ClassifyResult{PrefixMinusZero, "comment", lineNum}
+	}
+	if strings.HasPrefix(trimmed, `"""`) || strings.HasPrefix(trimmed, "'''") {
+		return ClassifyResult{PrefixMinusZero, "comment", lineNum}
+	}
+
+	if tok, ok := pythonKeywords[pythonToken(trimmed)]; ok {
+		return ClassifyResult{tok.symbol, tok.category, lineNum}
+	}
+
+	if strings.Contains(trimmed, "print(") {
+		return ClassifyResult{PrefixMinusOne, "io", lineNum}
+	}
+
+	if strings.Contains(trimmed, "=") && !strings.Contains(trimmed, "==") {
+		return ClassifyResult{PrefixPlusZero, "assignment", lineNum}
+	}
+
+	return ClassifyResult{PrefixMinusN, "unknown", lineNum}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ClassifyState threads the previous line's result into the next call to
+// LanguageClassifier.Classify, so a backend can make decisions based on
+// what came before (e.g. "are we still inside the block this opened").
+type ClassifyState struct {
+	Prev ClassifyResult
+}
+
+// LanguageClassifier maps source lines to quantum prefix symbols for one
+// language. The regex-based regexClassifier matches today's
+// strings.HasPrefix behavior; richer backends (Go, Python, JS/TS) can
+// use real parsing instead.
+type LanguageClassifier interface {
+	// Classify assigns a prefix to a single line, given the state left
+	// behind by the previous line in the same source.
+	Classify(line string, lineNum int, prev ClassifyState) ClassifyResult
+	// Capabilities describes what this backend can do, e.g.
+	// "ast", "tokenizer", "line-regex" — surfaced via GET /api/languages.
+	Capabilities() []string
+}
+
+// SourceClassifier is an optional, richer extension of LanguageClassifier
+// for backends that need the whole source at once (e.g. an AST walk)
+// rather than one line at a time.
+type SourceClassifier interface {
+	LanguageClassifier
+	ClassifySource(ctx context.Context, source string) ([]ClassifyResult, error)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]LanguageClassifier{}
+)
+
+// defaultLanguage is the registry key used when a request's Language is
+// empty or not registered.
+const defaultLanguage = ""
+
+// RegisterLanguageClassifier adds (or replaces) the backend used for
+// lang. Intended to be called from package-level init funcs, mirroring
+// the database/sql driver registration pattern.
+func RegisterLanguageClassifier(lang string, c LanguageClassifier) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[lang] = c
+}
+
+// lookupClassifier returns the backend registered for lang, falling
+// back to the regex-based default classifier for unknown languages.
+func lookupClassifier(lang string) LanguageClassifier {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if c, ok := registry[strings.ToLower(lang)]; ok {
+		return c
+	}
+	return registry[defaultLanguage]
+}
+
+func init() {
+	RegisterLanguageClassifier(defaultLanguage, regexClassifier{})
+	RegisterLanguageClassifier("go", goClassifier{})
+	RegisterLanguageClassifier("python", pythonClassifier{})
+	RegisterLanguageClassifier("py", pythonClassifier{})
+	RegisterLanguageClassifier("javascript", jsClassifier{})
+	RegisterLanguageClassifier("js", jsClassifier{})
+	RegisterLanguageClassifier("typescript", jsClassifier{})
+	RegisterLanguageClassifier("ts", jsClassifier{})
+}
+
+// ClassifySourceWithLanguage dispatches to the backend registered for
+// language, preferring its whole-source path (SourceClassifier) when
+// available and otherwise driving it one line at a time, honoring
+// ctx.Done() between lines exactly like ClassifySource.
+func ClassifySourceWithLanguage(ctx context.Context, language, source string) ([]ClassifyResult, error) {
+	backend := lookupClassifier(language)
+
+	if sc, ok := backend.(SourceClassifier); ok {
+		return sc.ClassifySource(ctx, source)
+	}
+
+	lines := strings.Split(source, "\n")
+	results := make([]ClassifyResult, len(lines))
+	var prev ClassifyResult
+	for i, line := range lines {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		prev = backend.Classify(line, i+1, ClassifyState{Prev: prev})
+		results[i] = prev
+	}
+	return results, nil
+}
+
+// regexClassifier is the original strings.HasPrefix chain, registered as
+// the fallback for unknown or unspecified languages.
+type regexClassifier struct{}
+
+func (regexClassifier) Classify(line string, lineNum int, _ ClassifyState) ClassifyResult {
+	return ClassifyLine(context.Background(), line, lineNum)
+}
+
+func (regexClassifier) Capabilities() []string { return []string{"line-regex"} }
+
+// languageInfo is what GET /api/languages reports for one backend.
+type languageInfo struct {
+	Language     string   `json:"language"`
+	Capabilities []string `json:"capabilities"`
+}
+
+// handleLanguages lists every registered backend and its capabilities.
+// The "" key is reported as "default" since that's the language clients
+// actually get when they omit or misspell the field.
+func handleLanguages(w http.ResponseWriter, _ *http.Request) {
+	registryMu.RLock()
+	infos := make([]languageInfo, 0, len(registry))
+	for lang, c := range registry {
+		name := lang
+		if name == defaultLanguage {
+			name = "default"
+		}
+		infos = append(infos, languageInfo{Language: name, Capabilities: c.Capabilities()})
+	}
+	registryMu.RUnlock()
+
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Language < infos[j].Language })
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"languages": infos})
+}
@@ -4,7 +4,9 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
@@ -39,8 +41,11 @@ type ClassifyResult struct {
 	Line     int          `json:"line"`
 }
 
-// ClassifyLine assigns a quantum prefix to a single line of code
-func ClassifyLine(line string, lineNum int) ClassifyResult {
+// ClassifyLine assigns a quantum prefix to a single line of code. ctx is
+// accepted for symmetry with ClassifySource and future backends that do
+// real parsing work per line; the regex classifier never blocks, so it
+// doesn't check ctx itself.
+func ClassifyLine(_ context.Context, line string, lineNum int) ClassifyResult {
 	trimmed := strings.TrimSpace(line)
 
 	if trimmed == "" {
@@ -101,29 +106,38 @@ func ClassifyLine(line string, lineNum int) ClassifyResult {
 	return ClassifyResult{PrefixMinusN, "unknown", lineNum}
 }
 
-// ClassifySource classifies all lines in source code
-func ClassifySource(source string) []ClassifyResult {
+// ClassifySource classifies all lines in source code, checking ctx
+// between lines so a canceled or timed-out request stops promptly
+// instead of running to completion on a huge blob.
+func ClassifySource(ctx context.Context, source string) ([]ClassifyResult, error) {
 	lines := strings.Split(source, "\n")
 	results := make([]ClassifyResult, len(lines))
 	for i, line := range lines {
-		results[i] = ClassifyLine(line, i+1)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		results[i] = ClassifyLine(ctx, line, i+1)
 	}
-	return results
+	return results, nil
 }
 
 // ━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
 // HTTP API
 // ━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
 
-// State tracks connected clients and global prefix state
+// State tracks connected WebSocket clients and the global prefix state
+// they're kept in sync with. Every mutation of global is broadcast to
+// clients as a SyncOp frame; see ws.go.
 type State struct {
-	mu       sync.RWMutex
-	clients  map[string]time.Time
-	global   map[string]json.RawMessage
+	mu      sync.RWMutex
+	clients map[string]*wsClient
+	global  map[string]json.RawMessage
 }
 
 var state = &State{
-	clients: make(map[string]time.Time),
+	clients: make(map[string]*wsClient),
 	global:  make(map[string]json.RawMessage),
 }
 
@@ -149,40 +163,67 @@ func handleHealth(w http.ResponseWriter, _ *http.Request) {
 	})
 }
 
-func handleClassify(w http.ResponseWriter, r *http.Request) {
+func handleState(w http.ResponseWriter, _ *http.Request) {
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(state.global)
+}
+
+// handleStateSet is the HTTP-only counterpart to the "set" WS op, for
+// callers that don't hold a socket open. It applies and broadcasts the
+// mutation exactly like a WS-originated set, with origin "http".
+func handleStateSet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
 	var req struct {
-		Source   string `json:"source"`
-		Language string `json:"language"`
+		Key   string          `json:"key"`
+		Value json.RawMessage `json:"value"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "invalid JSON", http.StatusBadRequest)
 		return
 	}
-	results := ClassifySource(req.Source)
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]any{
-		"results":  results,
-		"language": req.Language,
-		"lines":    len(results),
-	})
-}
+	if req.Key == "" {
+		http.Error(w, "key is required", http.StatusBadRequest)
+		return
+	}
+
+	state.applySet(req.Key, req.Value)
+	state.broadcast(SyncOp{Op: "set", Key: req.Key, Value: req.Value, Origin: "http"})
 
-func handleState(w http.ResponseWriter, _ *http.Request) {
-	state.mu.RLock()
-	defer state.mu.RUnlock()
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(state.global)
+	json.NewEncoder(w).Encode(map[string]any{"ok": true})
 }
 
 var startTime = time.Now()
 
+// classifyTimeout bounds how long a single classify call is allowed to
+// run before it's aborted with a 503, guarding against slow-loris POSTs
+// and pathological inputs pinning a goroutine indefinitely.
+var classifyTimeout time.Duration
+
 func main() {
 	port := "8085"
 
+	flag.DurationVar(&classifyTimeout, "classify-timeout", 5*time.Second, "max duration allotted to a single /api/classify request")
+	flag.Parse()
+
 	mux := http.NewServeMux()
 	mux.HandleFunc("/health", corsMiddleware(handleHealth))
-	mux.HandleFunc("/api/classify", corsMiddleware(handleClassify))
+	mux.HandleFunc("/api/languages", corsMiddleware(handleLanguages))
 	mux.HandleFunc("/api/state", corsMiddleware(handleState))
+	mux.HandleFunc("/api/state/set", corsMiddleware(handleStateSet))
+	mux.HandleFunc("/api/classify/stream", corsMiddleware(handleClassifyStream))
+	mux.HandleFunc("/ws", handleWS)
+	if err := mountGateway(mux); err != nil {
+		log.Fatalf("grpc-gateway: %v", err)
+	}
+
+	go serveGRPC()
 
 	fmt.Printf("⚛ uvspeed Go bridge server\n")
 	fmt.Printf("  {+1, 1, -1, +0, 0, -0, +n, n, -n}\n")
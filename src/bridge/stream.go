@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// streamScanBufSize caps how long a single source line can be. The
+// default bufio.Scanner limit (64KiB) is plenty for source code but we
+// size it explicitly since minified/generated lines can blow past it.
+const streamScanBufSize = 1 << 20
+
+// handleClassifyStream classifies the request body as it arrives rather
+// than buffering it into memory first, so a multi-megabyte source
+// doesn't OOM the process the way /api/classify's full-body JSON decode
+// would. Each line is written back immediately as its own NDJSON
+// ClassifyResult, coalesced into batches of ?batch=N before each flush,
+// and a trailing summary frame reports the total line count and a
+// histogram of symbols seen.
+func handleClassifyStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	batch := 1
+	if raw := r.URL.Query().Get("batch"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			batch = n
+		}
+	}
+	backend := lookupClassifier(r.URL.Query().Get("language"))
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Transfer-Encoding", "chunked")
+
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), streamScanBufSize)
+
+	enc := json.NewEncoder(w)
+	ctx := r.Context()
+	histogram := map[PrefixSymbol]int{}
+	var prev ClassifyResult
+	lineNum := 0
+	pending := 0
+
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		lineNum++
+		prev = backend.Classify(scanner.Text(), lineNum, ClassifyState{Prev: prev})
+		histogram[prev.Symbol]++
+
+		if err := enc.Encode(prev); err != nil {
+			return
+		}
+		pending++
+		if pending >= batch {
+			flusher.Flush()
+			pending = 0
+		}
+	}
+
+	if pending > 0 {
+		flusher.Flush()
+	}
+
+	summary := map[string]any{
+		"done":      scanner.Err() == nil,
+		"lines":     lineNum,
+		"histogram": histogram,
+	}
+	if err := scanner.Err(); err != nil {
+		summary["error"] = err.Error()
+	}
+	enc.Encode(summary)
+	flusher.Flush()
+}
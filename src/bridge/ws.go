@@ -0,0 +1,263 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WS protocol tuning. Mirrors the defaults gorilla/websocket examples use
+// for chat-style fan-out servers, tightened slightly for our sync payloads.
+const (
+	wsWriteWait      = 10 * time.Second
+	wsPongWait       = 60 * time.Second
+	wsPingPeriod     = (wsPongWait * 9) / 10
+	wsMaxMessageSize = 1 << 20 // 1MiB, generous for a JSON state frame
+	wsSendBufSize    = 32      // bounded per-client outbox; see State.send
+)
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+	CheckOrigin:     func(_ *http.Request) bool { return true },
+}
+
+// SyncOp is one mutation broadcast to every connected peer.
+type SyncOp struct {
+	Op     string          `json:"op"`
+	Key    string          `json:"key,omitempty"`
+	Value  json.RawMessage `json:"value,omitempty"`
+	Origin string          `json:"origin"`
+}
+
+// PresenceOp announces a client joining or leaving the pub/sub mesh.
+type PresenceOp struct {
+	Op       string `json:"op"`
+	ClientID string `json:"clientID"`
+	Clients  int    `json:"clients"`
+}
+
+// wsClient is one connected peer: a socket plus its bounded outbox.
+// mu/closed guard send against racing unregister's close(send) — every
+// send and the close itself happen under mu, so a send can never land
+// on an already-closed channel.
+type wsClient struct {
+	id   string
+	conn *websocket.Conn
+	send chan []byte
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// register adds a client to State.clients and announces its presence to
+// the rest of the mesh. Safe for concurrent use.
+func (s *State) register(c *wsClient) {
+	s.mu.Lock()
+	s.clients[c.id] = c
+	n := len(s.clients)
+	s.mu.Unlock()
+
+	s.broadcastPresence("join", c.id, n)
+}
+
+// unregister removes a client and closes its outbox. Safe to call more
+// than once for the same client.
+func (s *State) unregister(c *wsClient) {
+	s.mu.Lock()
+	if _, ok := s.clients[c.id]; !ok {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.clients, c.id)
+	n := len(s.clients)
+	s.mu.Unlock()
+
+	c.mu.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.send)
+	}
+	c.mu.Unlock()
+
+	s.broadcastPresence("leave", c.id, n)
+}
+
+// broadcast fans an op out to every connected client, including the one
+// it originated from, so every tab stays consistent.
+func (s *State) broadcast(op SyncOp) {
+	frame, err := json.Marshal(op)
+	if err != nil {
+		log.Printf("ws: marshal broadcast: %v", err)
+		return
+	}
+
+	s.mu.RLock()
+	clients := make([]*wsClient, 0, len(s.clients))
+	for _, c := range s.clients {
+		clients = append(clients, c)
+	}
+	s.mu.RUnlock()
+
+	for _, c := range clients {
+		s.send(c, frame)
+	}
+}
+
+func (s *State) broadcastPresence(op, clientID string, count int) {
+	frame, err := json.Marshal(PresenceOp{Op: "presence:" + op, ClientID: clientID, Clients: count})
+	if err != nil {
+		log.Printf("ws: marshal presence: %v", err)
+		return
+	}
+
+	s.mu.RLock()
+	clients := make([]*wsClient, 0, len(s.clients))
+	for _, c := range s.clients {
+		clients = append(clients, c)
+	}
+	s.mu.RUnlock()
+
+	for _, c := range clients {
+		s.send(c, frame)
+	}
+}
+
+// send delivers frame to c's outbox, evicting c if it's too slow to
+// drain. Holds c.mu for the whole attempt so it can never race
+// unregister's close(c.send).
+func (s *State) send(c *wsClient, frame []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	select {
+	case c.send <- frame:
+	default:
+		log.Printf("ws: client %s outbox full, evicting slow consumer", c.id)
+		go s.unregister(c)
+	}
+}
+
+// applySet writes key/value into the global state table under lock.
+func (s *State) applySet(key string, value json.RawMessage) {
+	s.mu.Lock()
+	s.global[key] = value
+	s.mu.Unlock()
+}
+
+// handleWS upgrades the connection and runs its read/write pumps until
+// the client disconnects.
+func handleWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("ws: upgrade failed: %v", err)
+		return
+	}
+
+	c := &wsClient{
+		id:   newClientID(),
+		conn: conn,
+		send: make(chan []byte, wsSendBufSize),
+	}
+
+	state.register(c)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		c.writePump()
+	}()
+	go func() {
+		defer wg.Done()
+		c.readPump()
+	}()
+	wg.Wait()
+}
+
+// readPump pumps inbound frames off the socket and applies/broadcasts
+// any set ops. Exits (and triggers cleanup) on any read error.
+func (c *wsClient) readPump() {
+	defer func() {
+		state.unregister(c)
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(wsMaxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		return nil
+	})
+
+	for {
+		_, raw, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNoStatusReceived) {
+				log.Printf("ws: client %s read error: %v", c.id, err)
+			}
+			return
+		}
+
+		var op SyncOp
+		if err := json.Unmarshal(raw, &op); err != nil {
+			continue
+		}
+		if op.Op != "set" {
+			continue
+		}
+		op.Origin = c.id
+		state.applySet(op.Key, op.Value)
+		state.broadcast(op)
+	}
+}
+
+// writePump drains c.send onto the socket and sends periodic pings,
+// closing the connection if either stalls past its deadline.
+func (c *wsClient) writePump() {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case frame, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, frame); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+var clientSeq uint64
+var clientSeqMu sync.Mutex
+
+// newClientID hands out short, process-unique client identifiers.
+func newClientID() string {
+	clientSeqMu.Lock()
+	clientSeq++
+	n := clientSeq
+	clientSeqMu.Unlock()
+	return time.Now().UTC().Format("20060102T150405.000000") + "-" + strconv.FormatUint(n, 10)
+}
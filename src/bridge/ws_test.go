@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func newTestState() *State {
+	return &State{
+		clients: make(map[string]*wsClient),
+		global:  make(map[string]json.RawMessage),
+	}
+}
+
+func TestStateSendEvictsSlowConsumer(t *testing.T) {
+	s := newTestState()
+	c := &wsClient{id: "slow", send: make(chan []byte, wsSendBufSize)}
+	s.clients[c.id] = c
+
+	// Fill the bounded outbox so the next send has nowhere to go.
+	for i := 0; i < wsSendBufSize; i++ {
+		s.send(c, []byte("frame"))
+	}
+
+	// This send finds a full channel and should evict c asynchronously
+	// instead of blocking.
+	s.send(c, []byte("one frame too many"))
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		s.mu.RLock()
+		_, stillRegistered := s.clients[c.id]
+		s.mu.RUnlock()
+		if !stillRegistered {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("slow consumer was never evicted")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestStateSendAfterUnregisterDoesNotPanic(t *testing.T) {
+	s := newTestState()
+	c := &wsClient{id: "gone", send: make(chan []byte, 1)}
+	s.clients[c.id] = c
+
+	s.unregister(c)
+
+	// Must not panic with "send on closed channel" even though
+	// unregister already closed c.send.
+	s.send(c, []byte("too late"))
+}
+
+func TestStateUnregisterIsIdempotent(t *testing.T) {
+	s := newTestState()
+	c := &wsClient{id: "dup", send: make(chan []byte, 1)}
+	s.clients[c.id] = c
+
+	s.unregister(c)
+	s.unregister(c) // must not double-close c.send
+}